@@ -0,0 +1,117 @@
+// Package ast defines the abstract syntax tree produced by the Myrmidon
+// frontend and consumed by the rest of gck.
+package ast
+
+import "math/big"
+
+// Operand identifies the operation a NodeOperand represents.
+type Operand int
+
+// Operand values, in the order the grammar produces them.
+const (
+	Eos Operand = iota
+	Program
+	Assign
+	While
+	If
+	Lt
+	Gt
+	Le
+	Ge
+	Ne
+	Eq
+	Uminus
+	Add
+	Sub
+	Mul
+	Div
+	Call
+	For
+	Break
+	Continue
+)
+
+// NodeDebugInformation carries source-location information for a Node,
+// used to produce human readable diagnostics.
+type NodeDebugInformation struct {
+	LineNo   int
+	ColStart int
+	ColEnd   int
+	Line     string
+}
+
+// Node is a single element of the AST. Value holds one of NodeOperand,
+// NodeInteger, NodeNumber, NodeIdentifier or NodeString, depending on
+// what was parsed.
+type Node struct {
+	Debug *NodeDebugInformation
+	Value interface{}
+}
+
+// NodeOperand is an operator or control-flow node with zero or more
+// child nodes.
+type NodeOperand struct {
+	Operand Operand
+	Nodes   []Node
+}
+
+// NodeInteger is an integer literal leaf.
+type NodeInteger struct {
+	Value int
+}
+
+// NodeNumber is a decimal literal leaf, kept exact via big.Rat.
+type NodeNumber struct {
+	Value *big.Rat
+}
+
+// NodeIdentifier is an identifier leaf.
+type NodeIdentifier struct {
+	Name string
+}
+
+// NodeString is a double-quoted string literal leaf, escapes already
+// resolved.
+type NodeString struct {
+	Value string
+}
+
+// NewOperand returns a Node wrapping a NodeOperand.
+func NewOperand(debug *NodeDebugInformation, op Operand, nodes ...Node) Node {
+	return Node{
+		Debug: debug,
+		Value: NodeOperand{Operand: op, Nodes: nodes},
+	}
+}
+
+// NewInteger returns a Node wrapping a NodeInteger.
+func NewInteger(debug *NodeDebugInformation, v int) Node {
+	return Node{Debug: debug, Value: NodeInteger{Value: v}}
+}
+
+// NewNumber returns a Node wrapping a NodeNumber.
+func NewNumber(debug *NodeDebugInformation, v *big.Rat) Node {
+	return Node{Debug: debug, Value: NodeNumber{Value: v}}
+}
+
+// NewIdentifier returns a Node wrapping a NodeIdentifier. debug may be
+// nil, e.g. for the identifier on the left of an assignment, which the
+// grammar builds without its own debug info.
+func NewIdentifier(debug *NodeDebugInformation, name string) Node {
+	return Node{Debug: debug, Value: NodeIdentifier{Name: name}}
+}
+
+// NewString returns a Node wrapping a NodeString.
+func NewString(debug *NodeDebugInformation, v string) Node {
+	return Node{Debug: debug, Value: NodeString{Value: v}}
+}
+
+// NewCall returns a Node wrapping a Call NodeOperand. Its first child is
+// always the callee identifier, followed by the argument expressions, so
+// downstream layers can dispatch on Nodes[0] and treat Nodes[1:] as args.
+func NewCall(debug *NodeDebugInformation, name string, args ...Node) Node {
+	nodes := make([]Node, 0, len(args)+1)
+	nodes = append(nodes, NewIdentifier(nil, name))
+	nodes = append(nodes, args...)
+	return NewOperand(debug, Call, nodes...)
+}
@@ -0,0 +1,185 @@
+package ast
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// UnmarshalError reports a problem decoding an AST into a Go struct. It
+// is anchored to the source location of the offending expression when
+// one is known.
+type UnmarshalError struct {
+	Field string
+	Debug *NodeDebugInformation
+	Msg   string
+}
+
+func (e *UnmarshalError) Error() string {
+	if e.Debug != nil {
+		return fmt.Sprintf("line %v,%v-%v: field %q: %v",
+			e.Debug.LineNo, e.Debug.ColStart, e.Debug.ColEnd, e.Field, e.Msg)
+	}
+	return fmt.Sprintf("field %q: %v", e.Field, e.Msg)
+}
+
+// Unmarshal walks root -- the AST returned by Myrmidon.AST -- and
+// populates out, a pointer to a struct, from its top-level assignments
+// (`name = expr`); the grammar has no separate var/const declaration, so
+// every top-level assignment can be bound. Fields are bound with an
+// `sml:"name"` tag: int, float64
+// and *big.Rat fields receive the result of constant-folding the bound
+// expression, and string fields require the expression to be a bare
+// identifier.
+//
+// This lets callers treat Myrmidon source as a config format on top of
+// the existing grammar without writing their own evaluator.
+func Unmarshal(root Node, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ast: Unmarshal requires a pointer to a struct, got %T", out)
+	}
+	return unmarshalStruct(topLevelAssigns(root), rv.Elem())
+}
+
+// topLevelAssigns flattens the Eos-joined statement list rooted at n and
+// returns the expression bound to each Assign target.
+func topLevelAssigns(n Node) map[string]Node {
+	assigns := make(map[string]Node)
+	for _, stmt := range flattenEos(n) {
+		op, ok := stmt.Value.(NodeOperand)
+		if !ok {
+			continue
+		}
+		switch op.Operand {
+		case Program:
+			for k, v := range topLevelAssigns(op.Nodes[0]) {
+				assigns[k] = v
+			}
+		case Assign:
+			if id, ok := op.Nodes[0].Value.(NodeIdentifier); ok {
+				assigns[id.Name] = op.Nodes[1]
+			}
+		}
+	}
+	return assigns
+}
+
+// flattenEos unfolds the left-recursive Eos(stmts, stmt) tree the
+// grammar builds for a statement list into an ordered slice.
+func flattenEos(n Node) []Node {
+	op, ok := n.Value.(NodeOperand)
+	if !ok || op.Operand != Eos || len(op.Nodes) != 2 {
+		return []Node{n}
+	}
+	return append(flattenEos(op.Nodes[0]), flattenEos(op.Nodes[1])...)
+}
+
+func unmarshalStruct(assigns map[string]Node, sv reflect.Value) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		f := st.Field(i)
+		tag := f.Tag.Get("sml")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		expr, ok := assigns[tag]
+		if !ok {
+			return &UnmarshalError{Field: tag, Msg: "no such top-level assignment"}
+		}
+		if err := decodeField(tag, expr, sv.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeField(field string, n Node, fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r, err := evalConst(n)
+		if err != nil {
+			return &UnmarshalError{Field: field, Debug: n.Debug, Msg: err.Error()}
+		}
+		if !r.IsInt() {
+			return &UnmarshalError{Field: field, Debug: n.Debug, Msg: "expression is not an integer"}
+		}
+		fv.SetInt(new(big.Int).Quo(r.Num(), r.Denom()).Int64())
+
+	case reflect.Float64, reflect.Float32:
+		r, err := evalConst(n)
+		if err != nil {
+			return &UnmarshalError{Field: field, Debug: n.Debug, Msg: err.Error()}
+		}
+		f, _ := r.Float64()
+		fv.SetFloat(f)
+
+	case reflect.String:
+		id, ok := n.Value.(NodeIdentifier)
+		if !ok {
+			return &UnmarshalError{Field: field, Debug: n.Debug, Msg: "expression is not an identifier"}
+		}
+		fv.SetString(id.Name)
+
+	case reflect.Ptr:
+		if fv.Type() != reflect.TypeOf((*big.Rat)(nil)) {
+			return &UnmarshalError{Field: field, Debug: n.Debug, Msg: fmt.Sprintf("unsupported field type %s", fv.Type())}
+		}
+		r, err := evalConst(n)
+		if err != nil {
+			return &UnmarshalError{Field: field, Debug: n.Debug, Msg: err.Error()}
+		}
+		fv.Set(reflect.ValueOf(r))
+
+	default:
+		return &UnmarshalError{Field: field, Debug: n.Debug, Msg: fmt.Sprintf("unsupported field type %s", fv.Type())}
+	}
+	return nil
+}
+
+// evalConst constant-folds a pure arithmetic expression (integer/number
+// leaves combined with Add, Sub, Mul, Div and Uminus) into a big.Rat. It
+// fails on anything that isn't a compile-time constant, e.g. an
+// identifier reference.
+func evalConst(n Node) (*big.Rat, error) {
+	switch v := n.Value.(type) {
+	case NodeInteger:
+		return new(big.Rat).SetInt64(int64(v.Value)), nil
+	case NodeNumber:
+		return v.Value, nil
+	case NodeOperand:
+		if v.Operand == Uminus {
+			a, err := evalConst(v.Nodes[0])
+			if err != nil {
+				return nil, err
+			}
+			return new(big.Rat).Neg(a), nil
+		}
+		if len(v.Nodes) == 2 {
+			a, err := evalConst(v.Nodes[0])
+			if err != nil {
+				return nil, err
+			}
+			b, err := evalConst(v.Nodes[1])
+			if err != nil {
+				return nil, err
+			}
+			r := new(big.Rat)
+			switch v.Operand {
+			case Add:
+				return r.Add(a, b), nil
+			case Sub:
+				return r.Sub(a, b), nil
+			case Mul:
+				return r.Mul(a, b), nil
+			case Div:
+				if b.Sign() == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				return r.Quo(a, b), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("not a constant expression")
+}
@@ -0,0 +1,70 @@
+package ast
+
+import (
+	"math/big"
+	"testing"
+)
+
+// node builds the Eos-joined statement list flattenEos expects.
+func node(stmts ...Node) Node {
+	n := stmts[0]
+	for _, s := range stmts[1:] {
+		n = NewOperand(nil, Eos, n, s)
+	}
+	return n
+}
+
+func assign(name string, expr Node) Node {
+	return NewOperand(nil, Assign, NewIdentifier(nil, name), expr)
+}
+
+func TestUnmarshalScalars(t *testing.T) {
+	root := node(
+		assign("port", NewInteger(nil, 8080)),
+		assign("ratio", NewOperand(nil, Div, NewInteger(nil, 1), NewInteger(nil, 4))),
+		assign("name", NewIdentifier(nil, "gck")),
+	)
+
+	var out struct {
+		Port  int      `sml:"port"`
+		Ratio *big.Rat `sml:"ratio"`
+		Name  string   `sml:"name"`
+	}
+
+	if err := Unmarshal(root, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Port != 8080 {
+		t.Errorf("Port = %d, want 8080", out.Port)
+	}
+	if out.Ratio.Cmp(big.NewRat(1, 4)) != 0 {
+		t.Errorf("Ratio = %v, want 1/4", out.Ratio)
+	}
+	if out.Name != "gck" {
+		t.Errorf("Name = %q, want gck", out.Name)
+	}
+}
+
+func TestUnmarshalMissingField(t *testing.T) {
+	root := node(assign("port", NewInteger(nil, 8080)))
+
+	var out struct {
+		Host string `sml:"host"`
+	}
+
+	if err := Unmarshal(root, &out); err == nil {
+		t.Fatal("expected error for missing field, got nil")
+	}
+}
+
+func TestUnmarshalNonConstant(t *testing.T) {
+	root := node(assign("port", NewIdentifier(nil, "x")))
+
+	var out struct {
+		Port int `sml:"port"`
+	}
+
+	if err := Unmarshal(root, &out); err == nil {
+		t.Fatal("expected error for non-constant expression, got nil")
+	}
+}
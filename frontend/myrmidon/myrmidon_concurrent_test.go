@@ -0,0 +1,49 @@
+package myrmidon
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestCompileConcurrent compiles hundreds of independent sources from
+// goroutines to guard against regressing on the package-level `d` lexer
+// hazard: every goroutine uses its own *Myrmidon, so nothing here should
+// serialize or race.
+func TestCompileConcurrent(t *testing.T) {
+	const n = 500
+
+	var wg sync.WaitGroup
+	errc := make(chan error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			m, err := New()
+			if err != nil {
+				errc <- fmt.Errorf("New: %v", err)
+				return
+			}
+
+			src := fmt.Sprintf("x = %d\nwhile x {\n  x = x - 1\n}\n", i)
+			if err := m.Compile(src); err != nil {
+				errc <- fmt.Errorf("Compile %d: %v", i, err)
+				return
+			}
+
+			if _, err := m.AST(); err != nil {
+				errc <- fmt.Errorf("AST %d: %v", i, err)
+				return
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errc)
+
+	for err := range errc {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,68 @@
+package sml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCollectsMultipleErrors(t *testing.T) {
+	// Two independent syntax errors on different lines, separated by a
+	// line that parses fine; the EOL-synchronizing `error EOL` production
+	// should let the second one surface instead of being swallowed by the
+	// first.
+	src := "x = )\ny = 1\nz = (\n"
+	lines := append([]string{""}, strings.Split(src, "\n")...)
+
+	_, err := Parse(src, lines)
+	if err == nil {
+		t.Fatal("expected errors, got nil")
+	}
+
+	el, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("err is %T, want ErrorList", err)
+	}
+	if len(el) < 2 {
+		t.Fatalf("got %d errors, want at least 2: %v", len(el), el)
+	}
+	if el[0].Line == el[len(el)-1].Line {
+		t.Fatalf("expected errors on distinct lines, both reported line %d", el[0].Line)
+	}
+}
+
+func TestParseReturnsBestEffortTreeOnError(t *testing.T) {
+	src := "x = )\ny = 1\n"
+	lines := append([]string{""}, strings.Split(src, "\n")...)
+
+	tree, err := Parse(src, lines)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if tree.Value == nil {
+		t.Fatal("expected a non-empty partial tree despite the error")
+	}
+}
+
+func TestErrorListRendersCaret(t *testing.T) {
+	el := ErrorList{
+		{Line: 1, ColStart: 5, ColEnd: 6, LineText: "x = )", Msg: "syntax error"},
+	}
+
+	got := el.Error()
+	lines := strings.Split(got, "\n")
+	if len(lines) < 3 {
+		t.Fatalf("Error() = %q, want at least 3 lines", got)
+	}
+	if lines[1] != "x = )" {
+		t.Errorf("source line = %q, want %q", lines[1], "x = )")
+	}
+	if lines[2] != "    ^" {
+		t.Errorf("caret line = %q, want %q", lines[2], "    ^")
+	}
+}
+
+func TestParseSingleErrorStillReported(t *testing.T) {
+	if err := parse("x = )\n"); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
@@ -3,50 +3,62 @@ package sml
 
 import __yyfmt__ "fmt"
 
-//line lang.y:3
+//line lang.y:2
+
 import (
 	"github.com/marcopeereboom/gck/ast"
 	"math/big"
 )
 
-var d *yylexer // being set so we don't have to type assert all the time
-
-//line lang.y:14
+//line lang.y:10
 type yySymType struct {
 	yys        int
 	integer    int
 	number     *big.Rat
 	identifier string
+	str        string
 	node       ast.Node
+	nodes      []ast.Node
 }
 
 const INTEGER = 57346
 const IDENTIFIER = 57347
-const VAR = 57348
-const CONST = 57349
-const NUMBER = 57350
-const WHILE = 57351
-const IF = 57352
-const ELSE = 57353
-const EOL = 57354
-const ASSIGN = 57355
-const LE = 57356
-const GE = 57357
-const NE = 57358
-const EQ = 57359
-const LT = 57360
-const GT = 57361
-const UMINUS = 57362
-
-var yyToknames = []string{
+const NUMBER = 57348
+const STRING = 57349
+const VAR = 57350
+const CONST = 57351
+const WHILE = 57352
+const IF = 57353
+const ELSE = 57354
+const FOR = 57355
+const BREAK = 57356
+const CONTINUE = 57357
+const EOL = 57358
+const ASSIGN = 57359
+const LE = 57360
+const GE = 57361
+const NE = 57362
+const EQ = 57363
+const LT = 57364
+const GT = 57365
+const UMINUS = 57366
+
+var yyToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
 	"INTEGER",
 	"IDENTIFIER",
+	"NUMBER",
+	"STRING",
 	"VAR",
 	"CONST",
-	"NUMBER",
 	"WHILE",
 	"IF",
 	"ELSE",
+	"FOR",
+	"BREAK",
+	"CONTINUE",
 	"EOL",
 	"ASSIGN",
 	"LE",
@@ -60,138 +72,190 @@ var yyToknames = []string{
 	"'*'",
 	"'/'",
 	"UMINUS",
+	"'{'",
+	"'}'",
+	"';'",
+	"'('",
+	"')'",
+	"','",
 }
-var yyStatenames = []string{}
+
+var yyStatenames = [...]string{}
 
 const yyEofCode = 1
 const yyErrCode = 2
-const yyMaxDepth = 200
+const yyInitialStackSize = 16
 
-//line lang.y:104
+//line lang.y:301
 
 //line yacctab:1
-var yyExca = []int{
+var yyExca = [...]int8{
 	-1, 1,
 	1, -1,
 	-2, 0,
+	-1, 2,
+	1, 1,
+	-2, 0,
 }
 
-const yyNprod = 33
 const yyPrivate = 57344
 
-var yyTokenNames []string
-var yyStates []string
-
-const yyLast = 126
-
-var yyAct = []int{
-
-	9, 8, 5, 57, 17, 42, 43, 44, 45, 40,
-	41, 20, 21, 22, 23, 16, 25, 27, 29, 29,
-	38, 22, 23, 33, 34, 35, 36, 37, 24, 39,
-	59, 17, 48, 47, 10, 12, 2, 1, 11, 15,
-	16, 6, 58, 51, 52, 53, 54, 55, 56, 3,
-	7, 13, 18, 0, 32, 4, 17, 49, 14, 0,
-	60, 61, 10, 12, 28, 0, 11, 15, 16, 20,
-	21, 22, 23, 10, 26, 0, 0, 11, 38, 13,
-	0, 31, 18, 4, 17, 0, 14, 10, 26, 0,
-	13, 11, 0, 0, 0, 46, 0, 14, 20, 21,
-	22, 23, 0, 50, 13, 20, 21, 22, 23, 0,
-	19, 30, 42, 43, 44, 45, 40, 41, 20, 21,
-	22, 23, 20, 21, 22, 23,
+const yyLast = 207
+
+var yyAct = [...]int8{
+	5, 66, 10, 73, 3, 72, 42, 25, 29, 30,
+	31, 32, 27, 28, 43, 36, 15, 82, 44, 45,
+	86, 43, 68, 57, 21, 37, 22, 23, 51, 52,
+	53, 54, 55, 56, 38, 39, 40, 41, 20, 40,
+	41, 50, 69, 62, 65, 24, 21, 37, 22, 23,
+	67, 2, 14, 35, 25, 58, 59, 60, 61, 71,
+	34, 29, 30, 31, 32, 27, 28, 24, 33, 78,
+	79, 74, 49, 26, 80, 29, 30, 31, 32, 27,
+	28, 81, 76, 84, 47, 85, 64, 88, 87, 13,
+	63, 21, 16, 22, 23, 83, 77, 17, 18, 46,
+	19, 11, 12, 4, 75, 29, 30, 31, 32, 27,
+	28, 8, 24, 9, 7, 6, 20, 1, 13, 14,
+	21, 16, 22, 23, 0, 0, 17, 18, 0, 19,
+	11, 12, 4, 29, 30, 31, 32, 27, 28, 0,
+	0, 24, 0, 0, 0, 20, 70, 13, 14, 21,
+	16, 22, 23, 0, 0, 17, 18, 0, 19, 11,
+	12, 4, 0, 0, 0, 0, 0, 0, 0, 0,
+	24, 0, 0, 0, 20, 48, 13, 14, 21, 16,
+	22, 23, 0, 0, 17, 18, 0, 19, 11, 12,
+	4, 0, 0, 0, 0, 0, 0, 0, 0, 24,
+	0, 0, 0, 20, 0, 0, 14,
 }
-var yyPact = []int{
-
-	58, -1000, 58, -1000, -1000, 85, -1000, -1000, -1000, -1000,
-	-1000, -1000, 15, 69, 69, 83, 83, 58, -1000, -1000,
-	69, 69, 69, 69, 69, -1000, -1000, 49, -22, 98,
-	83, -22, 30, -1, -1, -1000, -1000, 78, -1000, -1000,
-	69, 69, 69, 69, 69, 69, -26, -9, 19, -1000,
-	-1000, 102, 102, 102, 102, 102, 102, -1000, -1000, 5,
-	-1000, -1000,
+
+var yyPact = [...]int16{
+	174, -1000, 174, -1000, -1000, 57, -1000, -1000, -1000, -1000,
+	-1000, 52, 44, 37, 20, 10, -11, 20, 20, 79,
+	145, -1000, -1000, -1000, 42, -1000, -1000, 20, 20, 20,
+	20, 20, 20, -1000, -1000, -1000, -10, -18, 42, 42,
+	42, 42, 20, 20, 115, 87, -9, 25, -1000, 116,
+	-1000, 115, 115, 115, 115, 115, 115, -1000, 13, 13,
+	-1000, -1000, 43, -28, -31, 115, 174, 70, 20, 20,
+	-1000, -1000, -1000, 20, -1000, -1000, 174, -14, 115, 115,
+	115, -1000, 78, -1000, 3, 9, 20, -1000, 115,
 }
-var yyPgo = []int{
 
-	0, 49, 36, 2, 64, 50, 1, 42, 0, 41,
-	37,
+var yyPgo = [...]int8{
+	0, 117, 51, 4, 2, 115, 114, 113, 1, 111,
+	104, 99, 96, 95, 0, 16, 90, 86,
 }
-var yyR1 = []int{
 
-	0, 10, 1, 1, 1, 1, 1, 1, 2, 2,
-	8, 9, 5, 6, 7, 7, 7, 4, 4, 4,
-	4, 4, 4, 4, 3, 3, 3, 3, 3, 3,
-	3, 3, 3,
+var yyR1 = [...]int8{
+	0, 1, 2, 2, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 4, 4, 5, 6, 7, 8,
+	11, 11, 12, 12, 13, 13, 9, 10, 10, 14,
+	14, 14, 14, 14, 14, 14, 14, 15, 15, 15,
+	15, 15, 15, 15, 15, 15, 15, 16, 16, 17,
+	17,
 }
-var yyR2 = []int{
 
-	0, 1, 1, 2, 1, 1, 1, 1, 1, 2,
-	3, 4, 3, 4, 0, 2, 2, 3, 3, 3,
-	3, 3, 3, 3, 1, 1, 1, 2, 3, 3,
-	3, 3, 3,
+var yyR2 = [...]int8{
+	0, 1, 1, 2, 1, 2, 1, 1, 1, 1,
+	1, 2, 2, 2, 2, 3, 4, 4, 8, 0,
+	0, 3, 0, 1, 0, 3, 4, 0, 2, 3,
+	3, 3, 3, 3, 3, 3, 1, 1, 1, 1,
+	1, 4, 2, 3, 3, 3, 3, 0, 1, 1,
+	3,
 }
-var yyChk = []int{
-
-	-1000, -10, -2, -1, 25, -3, -9, -5, -6, -8,
-	4, 8, 5, 21, 28, 9, 10, 26, -1, 25,
-	20, 21, 22, 23, 13, -3, 5, -3, -4, -3,
-	28, -4, -2, -3, -3, -3, -3, -3, 29, -8,
-	18, 19, 14, 15, 16, 17, -4, -3, -8, 27,
-	25, -3, -3, -3, -3, -3, -3, 29, -7, 11,
-	-8, -6,
+
+var yyChk = [...]int16{
+	-1000, -1, -2, -3, 16, -14, -5, -6, -9, -7,
+	-4, 14, 15, 2, 32, -15, 5, 10, 11, 13,
+	29, 4, 6, 7, 25, -3, 16, 22, 23, 18,
+	19, 20, 21, 16, 16, 16, -14, 5, 24, 25,
+	26, 27, 17, 32, -14, -14, -11, 5, 30, -2,
+	-15, -14, -14, -14, -14, -14, -14, 33, -15, -15,
+	-15, -15, -14, -16, -17, -14, -8, -3, 31, 17,
+	30, 16, 33, 34, -3, -10, 12, -12, -14, -14,
+	-14, -3, 31, -13, 5, -8, 17, -4, -14,
 }
-var yyDef = []int{
-
-	0, -2, 1, 8, 2, 0, 4, 5, 6, 7,
-	24, 25, 26, 0, 0, 0, 0, 0, 9, 3,
-	0, 0, 0, 0, 0, 27, 26, 0, 0, 0,
-	0, 0, 0, 28, 29, 30, 31, 0, 32, 12,
-	0, 0, 0, 0, 0, 0, 0, 0, 14, 10,
-	11, 17, 18, 19, 20, 21, 22, 23, 13, 0,
-	15, 16,
+
+var yyDef = [...]int8{
+	0, -2, -2, 2, 4, 0, 6, 7, 8, 9,
+	10, 0, 0, 0, 0, 36, 40, 0, 0, 20,
+	0, 37, 38, 39, 0, 3, 5, 0, 0, 0,
+	0, 0, 0, 11, 12, 13, 0, 40, 0, 0,
+	0, 0, 0, 47, 19, 0, 0, 0, 14, 0,
+	42, 29, 30, 31, 32, 33, 34, 35, 43, 44,
+	45, 46, 0, 0, 48, 49, 0, 27, 22, 0,
+	15, 16, 41, 0, 17, 26, 0, 0, 23, 21,
+	50, 28, 24, 19, 0, 0, 0, 18, 25,
 }
-var yyTok1 = []int{
 
+var yyTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	28, 29, 22, 20, 3, 21, 3, 23, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 25,
+	32, 33, 26, 24, 34, 25, 3, 27, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 31,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 26, 3, 27,
+	3, 3, 3, 29, 3, 30,
 }
-var yyTok2 = []int{
 
+var yyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
-	12, 13, 14, 15, 16, 17, 18, 19, 24,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
+	22, 23, 28,
 }
-var yyTok3 = []int{
+
+var yyTok3 = [...]int8{
 	0,
 }
 
+var yyErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
 //line yaccpar:1
 
 /*	parser for yacc output	*/
 
-var yyDebug = 0
+var (
+	yyDebug        = 0
+	yyErrorVerbose = false
+)
 
 type yyLexer interface {
 	Lex(lval *yySymType) int
 	Error(s string)
 }
 
+type yyParser interface {
+	Parse(yyLexer) int
+	Lookahead() int
+}
+
+type yyParserImpl struct {
+	lval  yySymType
+	stack [yyInitialStackSize]yySymType
+	char  int
+}
+
+func (p *yyParserImpl) Lookahead() int {
+	return p.char
+}
+
+func yyNewParser() yyParser {
+	return &yyParserImpl{}
+}
+
 const yyFlag = -1000
 
 func yyTokname(c int) string {
-	// 4 is TOKSTART above
-	if c >= 4 && c-4 < len(yyToknames) {
-		if yyToknames[c-4] != "" {
-			return yyToknames[c-4]
+	if c >= 1 && c-1 < len(yyToknames) {
+		if yyToknames[c-1] != "" {
+			return yyToknames[c-1]
 		}
 	}
 	return __yyfmt__.Sprintf("tok-%v", c)
@@ -206,51 +270,127 @@ func yyStatname(s int) string {
 	return __yyfmt__.Sprintf("state-%v", s)
 }
 
-func yylex1(lex yyLexer, lval *yySymType) int {
-	c := 0
-	char := lex.Lex(lval)
+func yyErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !yyErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range yyErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + yyTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(yyPact[state])
+	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if yyDef[state] == -2 {
+		i := 0
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; yyExca[i] >= 0; i += 2 {
+			tok := int(yyExca[i])
+			if tok < TOKSTART || yyExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if yyExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += yyTokname(tok)
+	}
+	return res
+}
+
+func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
 	if char <= 0 {
-		c = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		c = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			c = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		c = yyTok3[i+0]
-		if c == char {
-			c = yyTok3[i+1]
+		token = int(yyTok3[i+0])
+		if token == char {
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
-	if c == 0 {
-		c = yyTok2[1] /* unknown char */
+	if token == 0 {
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
-		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(c), uint(char))
+		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
 	}
-	return c
+	return char, token
 }
 
 func yyParse(yylex yyLexer) int {
+	return yyNewParser().Parse(yylex)
+}
+
+func (yyrcvr *yyParserImpl) Parse(yylex yyLexer) int {
 	var yyn int
-	var yylval yySymType
 	var yyVAL yySymType
-	yyS := make([]yySymType, yyMaxDepth)
+	var yyDollar []yySymType
+	_ = yyDollar // silence set and not used
+	yyS := yyrcvr.stack[:]
 
 	Nerrs := 0   /* number of errors */
 	Errflag := 0 /* error recovery flag */
 	yystate := 0
-	yychar := -1
+	yyrcvr.char = -1
+	yytoken := -1 // yyrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		yystate = -1
+		yyrcvr.char = -1
+		yytoken = -1
+	}()
 	yyp := -1
 	goto yystack
 
@@ -263,7 +403,7 @@ ret1:
 yystack:
 	/* put a state and value onto the stack */
 	if yyDebug >= 4 {
-		__yyfmt__.Printf("char %v in %v\n", yyTokname(yychar), yyStatname(yystate))
+		__yyfmt__.Printf("char %v in %v\n", yyTokname(yytoken), yyStatname(yystate))
 	}
 
 	yyp++
@@ -276,21 +416,22 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
-	if yychar < 0 {
-		yychar = yylex1(yylex, &yylval)
+	if yyrcvr.char < 0 {
+		yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
 	}
-	yyn += yychar
+	yyn += yytoken
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yychar { /* valid shift */
-		yychar = -1
-		yyVAL = yylval
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
+		yyrcvr.char = -1
+		yytoken = -1
+		yyVAL = yyrcvr.lval
 		yystate = yyn
 		if Errflag > 0 {
 			Errflag--
@@ -300,27 +441,27 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
-		if yychar < 0 {
-			yychar = yylex1(yylex, &yylval)
+		if yyrcvr.char < 0 {
+			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
 		}
 
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
-			if yyn < 0 || yyn == yychar {
+			yyn = int(yyExca[xi+0])
+			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -329,11 +470,11 @@ yydefault:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
-			yylex.Error("syntax error")
+			yylex.Error(yyErrorMessage(yystate, yytoken))
 			Nerrs++
 			if yyDebug >= 1 {
 				__yyfmt__.Printf("%s", yyStatname(yystate))
-				__yyfmt__.Printf(" saw %s\n", yyTokname(yychar))
+				__yyfmt__.Printf(" saw %s\n", yyTokname(yytoken))
 			}
 			fallthrough
 
@@ -342,10 +483,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -361,12 +502,13 @@ yydefault:
 
 		case 3: /* no shift yet; clobber input char */
 			if yyDebug >= 2 {
-				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yychar))
+				__yyfmt__.Printf("error recovery discards %s\n", yyTokname(yytoken))
 			}
-			if yychar == yyEofCode {
+			if yytoken == yyEofCode {
 				goto ret1
 			}
-			yychar = -1
+			yyrcvr.char = -1
+			yytoken = -1
 			goto yynewstate /* try again in the same state */
 		}
 	}
@@ -380,184 +522,342 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
+	// yyp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if yyp+1 >= len(yyS) {
+		nyys := make([]yySymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+	}
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
 	switch yynt {
 
 	case 1:
-		//line lang.y:46
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:37
 		{
-			d.tree = yyS[yypt-0].node
+			d(yylex).tree = yyDollar[1].node
 		}
 	case 2:
-		//line lang.y:50
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:44
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Eos)
+			yyVAL.node = yyDollar[1].node
 		}
 	case 3:
-		//line lang.y:51
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:48
 		{
-			yyVAL.node = yyS[yypt-1].node
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos, yyDollar[1].node, yyDollar[2].node)
 		}
 	case 4:
-		//line lang.y:52
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:55
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos)
 		}
 	case 5:
-		//line lang.y:53
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:59
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = yyDollar[1].node
 		}
 	case 6:
-		//line lang.y:54
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:63
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = yyDollar[1].node
 		}
 	case 7:
-		//line lang.y:55
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:67
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = yyDollar[1].node
 		}
 	case 8:
-		//line lang.y:59
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:71
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = yyDollar[1].node
 		}
 	case 9:
-		//line lang.y:60
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:75
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Eos, yyS[yypt-1].node, yyS[yypt-0].node)
+			yyVAL.node = yyDollar[1].node
 		}
 	case 10:
-		//line lang.y:64
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:79
 		{
-			yyVAL.node = yyS[yypt-1].node
+			yyVAL.node = yyDollar[1].node
 		}
 	case 11:
-		//line lang.y:68
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:83
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Assign, ast.NewIdentifier(nil, yyS[yypt-3].identifier), yyS[yypt-1].node)
+			if d(yylex).loopDepth == 0 {
+				d(yylex).Errorf("break outside of loop")
+			}
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Break)
 		}
 	case 12:
-		//line lang.y:72
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:90
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.While, yyS[yypt-1].node, yyS[yypt-0].node)
+			if d(yylex).loopDepth == 0 {
+				d(yylex).Errorf("continue outside of loop")
+			}
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Continue)
 		}
 	case 13:
-		//line lang.y:75
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:97
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.If, yyS[yypt-2].node, yyS[yypt-1].node, yyS[yypt-0].node)
+			// Resynchronize on the next EOL after a syntax error instead of
+			// aborting, so a single malformed statement doesn't swallow every
+			// diagnostic after it.
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos)
 		}
 	case 14:
-		//line lang.y:78
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:107
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Eos)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos)
 		}
 	case 15:
-		//line lang.y:79
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:111
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = yyDollar[2].node
 		}
 	case 16:
-		//line lang.y:80
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line lang.y:118
 		{
-			yyVAL.node = yyS[yypt-0].node
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Assign, ast.NewIdentifier(nil, yyDollar[1].identifier), yyDollar[3].node)
 		}
 	case 17:
-		//line lang.y:84
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line lang.y:125
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Lt, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.While, yyDollar[2].node, yyDollar[4].node)
+			d(yylex).loopDepth--
 		}
 	case 18:
-		//line lang.y:85
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line lang.y:133
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Gt, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.For, yyDollar[2].node, yyDollar[4].node, yyDollar[6].node, yyDollar[8].node)
+			d(yylex).loopDepth--
 		}
 	case 19:
-		//line lang.y:86
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line lang.y:145
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Le, yyS[yypt-2].node, yyS[yypt-0].node)
+			d(yylex).loopDepth++
 		}
 	case 20:
-		//line lang.y:87
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line lang.y:152
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Ge, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos)
 		}
 	case 21:
-		//line lang.y:88
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:156
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Ne, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Assign, ast.NewIdentifier(nil, yyDollar[1].identifier), yyDollar[3].node)
 		}
 	case 22:
-		//line lang.y:89
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line lang.y:163
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Eq, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewInteger(d(yylex).d(), 1)
 		}
 	case 23:
-		//line lang.y:90
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:167
 		{
-			yyVAL.node = yyS[yypt-1].node
+			yyVAL.node = yyDollar[1].node
 		}
 	case 24:
-		//line lang.y:94
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line lang.y:174
 		{
-			yyVAL.node = ast.NewInteger(d.d(), yyS[yypt-0].integer)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos)
 		}
 	case 25:
-		//line lang.y:95
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:178
 		{
-			yyVAL.node = ast.NewNumber(d.d(), yyS[yypt-0].number)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Assign, ast.NewIdentifier(nil, yyDollar[1].identifier), yyDollar[3].node)
 		}
 	case 26:
-		//line lang.y:96
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line lang.y:185
 		{
-			yyVAL.node = ast.NewIdentifier(d.d(), yyS[yypt-0].identifier)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.If, yyDollar[2].node, yyDollar[3].node, yyDollar[4].node)
 		}
 	case 27:
-		//line lang.y:97
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line lang.y:192
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Uminus, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eos)
 		}
 	case 28:
-		//line lang.y:98
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:196
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Add, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = yyDollar[2].node
 		}
 	case 29:
-		//line lang.y:99
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:203
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Sub, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Lt, yyDollar[1].node, yyDollar[3].node)
 		}
 	case 30:
-		//line lang.y:100
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:207
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Mul, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Gt, yyDollar[1].node, yyDollar[3].node)
 		}
 	case 31:
-		//line lang.y:101
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:211
 		{
-			yyVAL.node = ast.NewOperand(d.d(), ast.Div, yyS[yypt-2].node, yyS[yypt-0].node)
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Le, yyDollar[1].node, yyDollar[3].node)
 		}
 	case 32:
-		//line lang.y:102
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:215
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Ge, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 33:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:219
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Ne, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 34:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:223
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Eq, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 35:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:227
+		{
+			yyVAL.node = yyDollar[2].node
+		}
+	case 36:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:231
+		{
+			yyVAL.node = yyDollar[1].node
+		}
+	case 37:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:238
+		{
+			yyVAL.node = ast.NewInteger(d(yylex).d(), yyDollar[1].integer)
+		}
+	case 38:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:242
+		{
+			yyVAL.node = ast.NewNumber(d(yylex).d(), yyDollar[1].number)
+		}
+	case 39:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:246
+		{
+			yyVAL.node = ast.NewString(d(yylex).d(), yyDollar[1].str)
+		}
+	case 40:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:250
+		{
+			yyVAL.node = ast.NewIdentifier(d(yylex).d(), yyDollar[1].identifier)
+		}
+	case 41:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line lang.y:254
+		{
+			yyVAL.node = ast.NewCall(d(yylex).d(), yyDollar[1].identifier, yyDollar[3].nodes...)
+		}
+	case 42:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line lang.y:258
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Uminus, yyDollar[2].node)
+		}
+	case 43:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:262
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Add, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 44:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:266
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Sub, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 45:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:270
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Mul, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 46:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:274
+		{
+			yyVAL.node = ast.NewOperand(d(yylex).d(), ast.Div, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 47:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line lang.y:281
+		{
+			yyVAL.nodes = nil
+		}
+	case 48:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:285
+		{
+			yyVAL.nodes = yyDollar[1].nodes
+		}
+	case 49:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line lang.y:292
+		{
+			yyVAL.nodes = []ast.Node{yyDollar[1].node}
+		}
+	case 50:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line lang.y:296
 		{
-			yyVAL.node = yyS[yypt-1].node
+			yyVAL.nodes = append(yyDollar[1].nodes, yyDollar[3].node)
 		}
 	}
 	goto yystack /* stack new state and value */
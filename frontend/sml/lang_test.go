@@ -0,0 +1,63 @@
+package sml
+
+import (
+	"strings"
+	"testing"
+)
+
+// parse wraps Parse with the line bookkeeping every caller (myrmidon, via
+// driver.LineGenerator) is expected to provide: 1-indexed, with a dummy
+// element at 0 so y.line can index directly into it.
+func parse(src string) error {
+	lines := append([]string{""}, strings.Split(src, "\n")...)
+	_, err := Parse(src, lines)
+	return err
+}
+
+func TestParseValid(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"string literal", `s = "hi\n"` + "\n"},
+		{"call no args", "x = rand()\n"},
+		{"call with args", "x = max(1, 2, y)\n"},
+		{"for loop", "for i = 0; i < 10; i = i + 1 {\n  x = i\n}\n"},
+		{"for loop with empty clauses", "for ; ; {\n  break\n}\n"},
+		{"else if chain", "if x < 1 {\n  x = 1\n} else if x < 2 {\n  x = 2\n} else {\n  x = 3\n}\n"},
+		{"break inside while", "while x {\n  break\n}\n"},
+		{"continue inside while", "while x {\n  continue\n}\n"},
+		{"break inside nested if inside for", "for ; x; {\n  if x {\n    break\n  }\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := parse(tt.src); err != nil {
+				t.Fatalf("parse(%q): %v", tt.src, err)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"unterminated string", "s = \"hi\n"},
+		{"trailing comma in call", "x = max(1,)\n"},
+		{"malformed for header", "for i = 0 i < 10; i = i + 1 {\n}\n"},
+		{"dangling else", "else {\n  x = 1\n}\n"},
+		{"break outside loop", "break\n"},
+		{"continue outside loop", "continue\n"},
+		{"break inside if but outside loop", "if x {\n  break\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := parse(tt.src); err == nil {
+				t.Fatalf("parse(%q): expected error, got nil", tt.src)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package sml
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// syntheticProgram builds n assignment statements ("v0 = 0", "v1 = 1", ...)
+// so the lexer sees roughly 4*n tokens without ever needing to parse
+// successfully; BenchmarkLex only exercises Lex, not yyParse.
+func syntheticProgram(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteString("v")
+		b.WriteString(strconv.Itoa(i))
+		b.WriteString(" = ")
+		b.WriteString(strconv.Itoa(i))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// BenchmarkLex lexes a ~1M-token synthetic program to guard against the
+// DFA lexer regressing back to the quadratic, rescan-prone behavior of
+// the byte-at-a-time lexer it replaced.
+func BenchmarkLex(b *testing.B) {
+	src := syntheticProgram(250000) // ~1M tokens: IDENT ASSIGN INTEGER EOL
+
+	for i := 0; i < b.N; i++ {
+		lex := newLexer(bufio.NewReader(strings.NewReader(src)))
+		lex.lines = make([]string, 1) // debug info is unused by this benchmark
+		var lval yySymType
+		for lex.Lex(&lval) != 0 {
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package sml
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// TestOversizedIntegerReportsError guards against a regression where an
+// integer literal too wide for strconv.Atoi took down the whole host
+// process via log.Fatal instead of failing just its own compile.
+func TestOversizedIntegerReportsError(t *testing.T) {
+	src := "x = 99999999999999999999999999999999999999\n"
+	lines := append([]string{""}, strings.Split(src, "\n")...)
+
+	if _, err := Parse(src, lines); err == nil {
+		t.Fatal("expected an error for an oversized integer literal, got nil")
+	}
+}
+
+// TestMalformedNumberReportsError exercises the NUMBER counterpart of the
+// same fallback: a token the grammar recognizes as NUMBER but that
+// big.Rat.SetString still rejects must also fail the compile, not the
+// process.
+func TestMalformedNumberReportsError(t *testing.T) {
+	lex := newLexer(bufio.NewReader(strings.NewReader("")))
+	lex.lines = []string{""}
+
+	var lval yySymType
+	tok := lex.number(&lval, "not-a-number")
+	if tok != NUMBER {
+		t.Fatalf("number() returned token %d, want NUMBER", tok)
+	}
+	if len(lex.errors) != 1 {
+		t.Fatalf("got %d errors, want 1", len(lex.errors))
+	}
+	if lval.number == nil || lval.number.Sign() != 0 {
+		t.Fatalf("lval.number = %v, want a zero fallback value", lval.number)
+	}
+}
+
+// TestTruncatedStringAtEOFReportsError hands string() a buffer holding
+// only an opening quote (length 1) and checks that it records an error
+// instead of panicking on the slice expression s[1:len(s)-1]. The
+// generated lexer itself never passes such a buffer: a literal cut off by
+// EOF aborts before the string rule runs, so the test calls string()
+// directly.
+func TestTruncatedStringAtEOFReportsError(t *testing.T) {
+	lex := newLexer(bufio.NewReader(strings.NewReader("")))
+	lex.lines = []string{""}
+
+	var lval yySymType
+	tok := lex.string(&lval, "\"")
+	if tok != STRING {
+		t.Fatalf("string() returned token %d, want STRING", tok)
+	}
+	if len(lex.errors) != 1 {
+		t.Fatalf("got %d errors, want 1", len(lex.errors))
+	}
+	if lval.str != "" {
+		t.Fatalf("lval.str = %q, want empty fallback value", lval.str)
+	}
+}
@@ -0,0 +1,266 @@
+package sml
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/marcopeereboom/gck/ast"
+)
+
+// yylexer implements the lexer interface. Token scanning itself is golex
+// output (see lang.l, regenerated into lex.go); this file is everything
+// around it golex doesn't generate: construction, debug info, error
+// plumbing, and the scanner's primitives (getc/skip) and token
+// constructors that lex.go's rule actions call by name.
+type yylexer struct {
+	src       *bufio.Reader // reader to the code
+	buf       []byte        // contains currently lexed bytes
+	current   byte          // current byte we are lexing
+	errors    ErrorList     // every error we saw, in the order we saw them
+	line      int           // line we are parsing
+	lines     []string      // lines, used for debug etc
+	colStart  int           // column where token starts
+	colEnd    int           // column where token ends
+	loopDepth int           // number of while/for bodies currently being parsed
+
+	tree ast.Node // AST representation of the provided code
+}
+
+// CompileError is a single diagnostic produced while parsing Myrmidon
+// source, anchored to the line and column range of the offending token.
+type CompileError struct {
+	Line     int
+	ColStart int
+	ColEnd   int
+	LineText string
+	Msg      string
+}
+
+// Error renders a single-line form of the diagnostic; ErrorList.Error
+// is what callers normally see, since a compile produces zero or more
+// of these.
+func (e CompileError) Error() string {
+	return fmt.Sprintf("line %v,%v-%v: %v", e.Line, e.ColStart, e.ColEnd, e.Msg)
+}
+
+// ErrorList is every CompileError a single Parse call collected, in the
+// order the parser hit them. It satisfies error so existing callers that
+// only check err != nil keep working unchanged.
+type ErrorList []CompileError
+
+// Error pretty-prints each diagnostic as three lines: the message, the
+// offending source line, and a caret under the column it starts at.
+func (el ErrorList) Error() string {
+	var b strings.Builder
+	for i, e := range el {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "line %v,%v-%v: %v\n", e.Line, e.ColStart, e.ColEnd, e.Msg)
+		b.WriteString(e.LineText)
+		b.WriteByte('\n')
+		col := e.ColStart
+		if col < 1 {
+			col = 1
+		}
+		b.WriteString(strings.Repeat(" ", col-1))
+		b.WriteByte('^')
+	}
+	return b.String()
+}
+
+// newLexer returns a yylexer context.
+func newLexer(src *bufio.Reader) *yylexer {
+	y := yylexer{
+		line: 1,
+		src:  src,
+	}
+
+	if b, err := src.ReadByte(); err == nil {
+		y.current = b
+		y.colEnd++
+	}
+
+	return &y
+}
+
+// d type-asserts yylex into *yylexer. It exists so that grammar actions
+// can reach lexer state through the yylex argument yyParse already
+// passes them, instead of relying on package-level mutable state. Every
+// action calls it, hence the short name.
+func d(yylex yyLexer) *yylexer {
+	return yylex.(*yylexer)
+}
+
+// d generates debug information, short name to keep yacc code readable.
+func (y *yylexer) d() *ast.NodeDebugInformation {
+	return &ast.NodeDebugInformation{
+		LineNo:   y.line,
+		ColStart: y.colStart,
+		ColEnd:   y.colEnd,
+		Line:     y.lines[y.line],
+	}
+}
+
+// Error records a diagnostic from a string. yyParse calls this itself on
+// every syntax error (see yyErrorMessage in lang.go); grammar actions
+// call Errorf for semantic checks such as break/continue placement.
+// Unlike the single lastError this replaced, every call appends, so a
+// compile that hits several unrelated problems reports all of them.
+func (y *yylexer) Error(e string) {
+	y.append(e)
+}
+
+// Errorf records a diagnostic using standard formatting rules.
+func (y *yylexer) Errorf(format string, args ...interface{}) {
+	y.append(fmt.Sprintf(format, args...))
+}
+
+// append appends a CompileError anchored to the lexer's current
+// position to errors.
+func (y *yylexer) append(msg string) {
+	lineText := ""
+	if y.line >= 0 && y.line < len(y.lines) {
+		lineText = y.lines[y.line]
+	}
+	y.errors = append(y.errors, CompileError{
+		Line:     y.line,
+		ColStart: y.colStart,
+		ColEnd:   y.colEnd,
+		LineText: lineText,
+		Msg:      msg,
+	})
+}
+
+// Parse lexes and parses src and returns the resulting AST. lines is used
+// to generate human readable diagnostics and is indexed by 1-based line
+// number: lines[i] holds line i of src, lines[0] is unused, and the
+// element after the last newline must be present as well. Parse owns a
+// fresh yylexer per call, so distinct calls
+// (even concurrent ones) never share state.
+//
+// The grammar resynchronizes on EOL after a syntax error (see the
+// `error EOL` production in lang.y), so a single malformed statement
+// doesn't prevent later ones from being checked too; Parse returns every
+// error it collected along the way as an ErrorList. The returned Node is
+// a best-effort tree: when recovery succeeds it's the full parse despite
+// the errors, so tooling (LSP-style consumers) can still operate on
+// broken input.
+func Parse(src string, lines []string) (ast.Node, error) {
+	r := bufio.NewReader(strings.NewReader(src))
+	lex := newLexer(r)
+	lex.lines = lines
+
+	yyParse(lex)
+
+	if len(lex.errors) > 0 {
+		return lex.tree, lex.errors
+	}
+
+	return lex.tree, nil
+}
+
+// getc returns the next byte from the reader, accumulating the
+// already-seen byte into the current token buffer. lang.l names this as
+// the %yyn action that advances golex's generated DFA.
+func (y *yylexer) getc() byte {
+	if y.current != 0 {
+		y.buf = append(y.buf, y.current)
+	}
+	y.current = 0
+	if b, err := y.src.ReadByte(); err == nil {
+		y.current = b
+		y.colEnd++
+	}
+	return y.current
+}
+
+// skip discards the accumulated token buffer without producing a token.
+// lang.l calls this once per token attempt, right before the generated
+// DFA starts matching, so it also runs after whitespace, whose rule loops
+// back without returning.
+func (y *yylexer) skip() {
+	y.buf = y.buf[:0]
+	y.colStart = y.colEnd
+}
+
+// newline implements the {nl} action in lang.l: it advances the line
+// counter and resets column tracking for the line that follows.
+func (y *yylexer) newline() {
+	y.line++
+	y.colStart, y.colEnd = 1, 1
+}
+
+// eol implements the rest of the {nl} action in lang.l, returning EOL.
+// It takes lval for symmetry with the other token constructors even
+// though EOL carries no semantic value.
+func (y *yylexer) eol(lval *yySymType) int {
+	return EOL
+}
+
+// number returns NUMBER and sets the union of the parser to the value of s.
+// A malformed literal is reported through Errorf rather than crashing the
+// lexer, since this frontend must stay safe to embed in a long-running
+// host process: one bad input must never take down more than its own
+// compile.
+func (y *yylexer) number(val *yySymType, s string) int {
+	var ok bool
+	val.number, ok = new(big.Rat).SetString(s)
+	if !ok {
+		y.Errorf("invalid number %s", s)
+		val.number = new(big.Rat)
+	}
+	return NUMBER
+}
+
+// identifier returns IDENTIFIER and sets the union of the parser to the
+// value of s. lang.l lists every keyword as its own quoted-string rule
+// ahead of the identifier regex, so by the time this runs s is never a
+// keyword: golex's longest-match-then-earliest-rule semantics already
+// routed those to their own token constants.
+func (y *yylexer) identifier(val *yySymType, s string) int {
+	val.identifier = s
+	return IDENTIFIER
+}
+
+// string returns STRING and sets the union of the parser to the unescaped
+// contents of the quoted literal s (still carrying its surrounding quotes,
+// as left by the lang.l string rule). A malformed escape is reported
+// through Errorf rather than crashing the lexer, since unlike
+// integer/number literals the regex in lang.l doesn't guarantee s is
+// well-formed (it admits any escaped byte and raw newlines). The lexer
+// only calls string() once a closing quote has matched; a literal cut off
+// by EOF never reaches it and is dropped without an error. The fallback
+// below still checks the length before slicing so a shorter s cannot
+// panic.
+func (y *yylexer) string(val *yySymType, s string) int {
+	unquoted, err := strconv.Unquote(s)
+	if err != nil {
+		y.Errorf("invalid string literal %s: %v", s, err)
+		if len(s) >= 2 {
+			unquoted = s[1 : len(s)-1]
+		} else {
+			unquoted = ""
+		}
+	}
+	val.str = unquoted
+	return STRING
+}
+
+// integer returns INTEGER and sets the union of the parser to the value of
+// s. A malformed or overflowing literal (e.g. one wider than int) is
+// reported through Errorf rather than crashing the lexer, since this
+// frontend must stay safe to embed in a long-running host process: one
+// bad input must never take down more than its own compile.
+func (y *yylexer) integer(val *yySymType, s string) int {
+	var err error
+	val.integer, err = strconv.Atoi(s)
+	if err != nil {
+		y.Errorf("invalid integer %s: %v", s, err)
+		val.integer = 0
+	}
+	return INTEGER
+}